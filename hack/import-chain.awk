@@ -0,0 +1,37 @@
+# Prints the shortest import chain from one of the root packages to target.
+#
+# Usage: go list -deps -f '{{.ImportPath}}{{range .Imports}} {{.}}{{end}}' <pkgs> \
+#            | awk -v roots="<root import paths>" -v target=<import path> -f hack/import-chain.awk
+#
+# Each input line is a package followed by the packages it imports.
+
+{
+	imports[$1] = $0
+}
+
+END {
+	n = split(roots, queue, " ")
+	for (i = 1; i <= n; i++) {
+		seen[queue[i]] = 1
+	}
+	for (head = 1; head <= n; head++) {
+		pkg = queue[head]
+		if (pkg == target) {
+			chain = pkg
+			while (pkg in parent) {
+				pkg = parent[pkg]
+				chain = pkg " -> " chain
+			}
+			print chain
+			exit
+		}
+		m = split(imports[pkg], deps, " ")
+		for (j = 2; j <= m; j++) {
+			if (!(deps[j] in seen)) {
+				seen[deps[j]] = 1
+				parent[deps[j]] = pkg
+				queue[++n] = deps[j]
+			}
+		}
+	}
+}
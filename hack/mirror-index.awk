@@ -0,0 +1,73 @@
+# Renders mirrors/index.json from operators.yaml and each mirror's go.mod.
+#
+# Usage: awk -f hack/mirror-index.awk operators.yaml
+#
+# Only operators that have a generated mirror under mirrors/<slug> are listed.
+
+function modulepath(slug,    file, line, f) {
+	file = "mirrors/" slug "/go.mod"
+	while ((getline line < file) > 0) {
+		if (line ~ /^module /) {
+			split(line, f, " ")
+			close(file)
+			return f[2]
+		}
+	}
+	close(file)
+	return ""
+}
+
+function flush(    mod, i) {
+	if (slug == "") {
+		return
+	}
+	mod = modulepath(slug)
+	if (mod == "") {
+		return
+	}
+	printf "%s\n    {\n", sep
+	printf "      \"slug\": \"%s\",\n", slug
+	printf "      \"repo\": \"%s\",\n", repo
+	printf "      \"version\": \"%s\",\n", version
+	printf "      \"tag\": \"mirrors/%s/%s\",\n", slug, version
+	printf "      \"modulePath\": \"%s\",\n", mod
+	printf "      \"apiPaths\": ["
+	for (i = 1; i <= npaths; i++) {
+		printf "%s\"%s\"", (i > 1 ? ", " : ""), paths[i]
+	}
+	printf "]\n    }"
+	sep = ","
+}
+
+function value(    v) {
+	v = $0
+	sub(/^[^:]*:[ \t]*/, "", v)
+	gsub(/"/, "", v)
+	return v
+}
+
+BEGIN {
+	printf "{\n  \"mirrors\": ["
+}
+
+/^- slug:/ {
+	flush()
+	slug = value(); repo = ""; version = ""; npaths = 0; inpaths = 0
+	next
+}
+
+/^  repo:/ { repo = value(); inpaths = 0; next }
+/^  currentVersion:/ { version = value(); inpaths = 0; next }
+/^  apiPaths:/ { inpaths = 1; next }
+/^  [A-Za-z]/ { inpaths = 0; next }
+
+inpaths && /^  - / {
+	p = $2
+	gsub(/"/, "", p)
+	paths[++npaths] = p
+}
+
+END {
+	flush()
+	printf "\n  ]\n}\n"
+}